@@ -0,0 +1,33 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Command autoscaler-agent is the stateless half of the agent/server
+// split: it fetches metrics for its configured services and executes the
+// ScaleCommands autoscaler-server sends back.
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/rmonvfer/autoscaler/internal/agent"
+)
+
+func main() {
+	cfg := agent.LoadConfig()
+	if err := agent.Run(context.Background(), cfg); err != nil {
+		log.Fatalf("agent: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Command autoscaler-server holds the policy state for every service a
+// fleet of autoscaler-agents reports on, and centrally decides when each
+// one should scale.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"os"
+
+	"github.com/rmonvfer/autoscaler/internal/server"
+)
+
+func main() {
+	addr := envOr("AUTOSCALER_LISTEN_ADDR", ":8443")
+	secret := os.Getenv("AUTOSCALER_SECRET")
+	if secret == "" {
+		log.Fatalf("missing env AUTOSCALER_SECRET")
+	}
+	configPath := envOr("AUTOSCALER_CONFIG", "autoscaler.yaml")
+
+	certPath := os.Getenv("AUTOSCALER_TLS_CERT")
+	keyPath := os.Getenv("AUTOSCALER_TLS_KEY")
+	if certPath == "" || keyPath == "" {
+		log.Fatalf("missing env AUTOSCALER_TLS_CERT/AUTOSCALER_TLS_KEY: the Hello frame carries AUTOSCALER_SECRET in cleartext without TLS")
+	}
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		log.Fatalf("server: load TLS keypair: %v", err)
+	}
+
+	f, err := os.Open(configPath)
+	if err != nil {
+		log.Fatalf("server: open config %s: %v", configPath, err)
+	}
+	defer f.Close()
+
+	policies, err := server.LoadConfig(f)
+	if err != nil {
+		log.Fatalf("server: %v", err)
+	}
+
+	ln, err := tls.Listen("tcp", addr, &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		log.Fatalf("server: listen %s: %v", addr, err)
+	}
+	log.Printf("server: listening on %s for %d services", addr, len(policies))
+
+	srv := server.NewServer(secret, policies)
+	if err := srv.Serve(context.Background(), ln); err != nil {
+		log.Fatalf("server: %v", err)
+	}
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,470 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rmonvfer/autoscaler/internal/audit"
+	"github.com/rmonvfer/autoscaler/internal/health"
+	"github.com/rmonvfer/autoscaler/internal/leader"
+	"github.com/rmonvfer/autoscaler/internal/metrics"
+	"github.com/rmonvfer/autoscaler/internal/policy"
+	"github.com/rmonvfer/autoscaler/internal/railway"
+)
+
+// Configuration
+type config struct {
+	Token     string
+	ServiceID string
+	High, Low float64
+	Min, Max  int
+	Cooldown  time.Duration
+	Interval  time.Duration
+
+	// HA enables leader-election-before-acting so that multiple
+	// autoscaler instances can point at the same ServiceID safely.
+	HA            bool
+	LeaderBackend string // "memory" or "redis"
+	RedisAddr     string
+	IdentityPath  string
+
+	// Strategy selects the policy.Strategy: "threshold" (default), "ewma"
+	// or "pid". The EWMA/PID knobs below are only read for their strategy.
+	Strategy  string
+	EWMAAlpha float64
+	// Reducer selects policy.Reducer for the "ewma" strategy: "max"
+	// (default) or "weighted_sum". EWMAWeights, keyed by signal name (e.g.
+	// "cpu", "memory"), only matters for "weighted_sum"; a signal absent
+	// from it defaults to weight 1.
+	Reducer     string
+	EWMAWeights map[string]float64
+	PIDKp       float64
+	PIDKi       float64
+	PIDKd       float64
+	PIDTarget   float64
+	StatePath   string
+	DebugPort   int
+
+	// MetricsPort serves /metrics, /healthz and /readyz. 0 disables it.
+	MetricsPort        int
+	AuditWebhookURL    string
+	AuditWebhookSecret string
+}
+
+// leaderStatus is the record written to the KV store on every iteration
+// so followers (and operators) can see what the leader is doing.
+type leaderStatus struct {
+	Holder       string    `json:"holder"`
+	LastDecision int       `json:"lastDecision"`
+	LastScale    time.Time `json:"lastScale,omitempty"`
+	Replicas     int       `json:"replicas"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func loadConfig() config {
+	must := func(key string) string {
+		v := os.Getenv(key)
+		if v == "" {
+			log.Fatalf("missing env %s", key)
+		}
+		return v
+	}
+	parseF := func(k string, def float64) float64 {
+		if v := os.Getenv(k); v != "" {
+			f, _ := strconv.ParseFloat(v, 64)
+			return f
+		}
+		return def
+	}
+	parseI := func(k string, def int) int {
+		if v := os.Getenv(k); v != "" {
+			i, _ := strconv.Atoi(v)
+			return i
+		}
+		return def
+	}
+	parseDur := func(k string, def time.Duration) time.Duration {
+		if v := os.Getenv(k); v != "" {
+			d, _ := time.ParseDuration(v)
+			return d
+		}
+		return def
+	}
+	parseB := func(k string, def bool) bool {
+		if v := os.Getenv(k); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err == nil {
+				return b
+			}
+		}
+		return def
+	}
+	parseWeights := func(k string) map[string]float64 {
+		v := os.Getenv(k)
+		if v == "" {
+			return nil
+		}
+		weights := make(map[string]float64)
+		for _, pair := range strings.Split(v, ",") {
+			signal, weight, ok := strings.Cut(pair, "=")
+			if !ok {
+				continue
+			}
+			if f, err := strconv.ParseFloat(strings.TrimSpace(weight), 64); err == nil {
+				weights[strings.TrimSpace(signal)] = f
+			}
+		}
+		return weights
+	}
+	return config{
+		Token:     must("RAILWAY_TOKEN"),
+		ServiceID: must("SERVICE_ID"),
+		High:      parseF("CPU_HIGH", 75),
+		Low:       parseF("CPU_LOW", 30),
+		Min:       parseI("MIN_REPLICAS", 1),
+		Max:       parseI("MAX_REPLICAS", 5),
+		Cooldown:  parseDur("COOLDOWN", 2*time.Minute),
+		Interval:  parseDur("POLL_INTERVAL", 30*time.Second),
+
+		HA:            parseB("HA_ENABLED", false),
+		LeaderBackend: os.Getenv("LEADER_BACKEND"),
+		RedisAddr:     os.Getenv("REDIS_ADDR"),
+		IdentityPath:  envOr("IDENTITY_PATH", "autoscaler-identity.json"),
+
+		Strategy:    envOr("STRATEGY", "threshold"),
+		EWMAAlpha:   parseF("EWMA_ALPHA", 0.3),
+		Reducer:     envOr("REDUCER", string(policy.ReducerMax)),
+		EWMAWeights: parseWeights("EWMA_WEIGHTS"),
+		PIDKp:       parseF("PID_KP", 0.1),
+		PIDKi:       parseF("PID_KI", 0.01),
+		PIDKd:       parseF("PID_KD", 0),
+		PIDTarget:   parseF("PID_TARGET", 50),
+		StatePath:   os.Getenv("POLICY_STATE_PATH"),
+		DebugPort:   parseI("DEBUG_PORT", 0),
+
+		MetricsPort:        parseI("METRICS_PORT", 9090),
+		AuditWebhookURL:    os.Getenv("AUDIT_WEBHOOK_URL"),
+		AuditWebhookSecret: os.Getenv("AUDIT_WEBHOOK_SECRET"),
+	}
+}
+
+// buildStrategy selects and configures the policy.Strategy named by
+// cfg.Strategy, wrapping it so its decision trace is always available.
+func buildStrategy(cfg config) *policy.Recorder {
+	switch cfg.Strategy {
+	case "ewma":
+		return policy.NewRecorder(policy.NewEWMAStrategy(policy.EWMAParams{
+			Alpha:     cfg.EWMAAlpha,
+			High:      map[string]float64{"cpu": cfg.High, "memory": cfg.High},
+			Low:       map[string]float64{"cpu": cfg.Low, "memory": cfg.Low},
+			Weights:   cfg.EWMAWeights,
+			Reducer:   policy.Reducer(cfg.Reducer),
+			Min:       cfg.Min,
+			Max:       cfg.Max,
+			StatePath: cfg.StatePath,
+		}))
+	case "pid":
+		return policy.NewRecorder(policy.NewPIDStrategy(policy.PIDParams{
+			Kp: cfg.PIDKp, Ki: cfg.PIDKi, Kd: cfg.PIDKd,
+			Target:    cfg.PIDTarget,
+			Min:       cfg.Min,
+			Max:       cfg.Max,
+			StatePath: cfg.StatePath,
+		}))
+	default:
+		return policy.NewRecorder(policy.ThresholdStrategy{Params: policy.ThresholdParams{
+			High: cfg.High, Low: cfg.Low, Min: cfg.Min, Max: cfg.Max,
+		}})
+	}
+}
+
+// serveDebug exposes the strategy's last decision trace at /debug when
+// cfg.DebugPort is set.
+func serveDebug(cfg config, recorder *policy.Recorder) {
+	if cfg.DebugPort == 0 {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/debug", recorder)
+	addr := ":" + strconv.Itoa(cfg.DebugPort)
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("debug server: %v", err)
+		}
+	}()
+}
+
+// serveObservability exposes /metrics, /healthz and /readyz when
+// cfg.MetricsPort is set. Readiness is tied to tracker, which the caller
+// updates on every successful fetch and (in HA mode) every standby tick.
+func serveObservability(cfg config, m *metrics.Metrics, tracker *health.Tracker) {
+	if cfg.MetricsPort == 0 {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m)
+	mux.HandleFunc("/healthz", tracker.LivezHandler)
+	mux.HandleFunc("/readyz", tracker.ReadyHandler)
+	addr := ":" + strconv.Itoa(cfg.MetricsPort)
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("metrics server: %v", err)
+		}
+	}()
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// main dispatches to the standalone single-service autoscaler, which
+// remains this binary's default behavior for simple deployments. Larger
+// multi-service deployments use the autoscaler-agent/autoscaler-server
+// split instead (see proto/ and internal/agent, internal/server).
+func main() {
+	mode := "standalone"
+	if len(os.Args) > 1 {
+		mode = os.Args[1]
+	}
+	switch mode {
+	case "standalone":
+		runStandalone()
+	default:
+		log.Fatalf("unknown mode %q: use \"standalone\", or run the autoscaler-agent/autoscaler-server binaries for multi-service deployments", mode)
+	}
+}
+
+func runStandalone() {
+	cfg := loadConfig()
+	ctx := context.Background()
+	client := railway.NewClient(cfg.Token)
+	strategy := buildStrategy(cfg)
+	serveDebug(cfg, strategy)
+
+	m := metrics.New()
+	tracker := health.NewTracker(3 * cfg.Interval)
+	serveObservability(cfg, m, tracker)
+	auditLog := audit.NewLogger(cfg.AuditWebhookURL, cfg.AuditWebhookSecret)
+
+	lastScale := time.Now().Add(-cfg.Cooldown)
+
+	var elector *leader.Elector
+	var holding <-chan struct{}
+	if cfg.HA {
+		stopStandby := markStandbyWhileWaiting(tracker, cfg.Interval)
+		var err error
+		elector, holding, err = startCampaign(ctx, cfg)
+		stopStandby()
+		if err != nil {
+			log.Fatalf("leader: %v", err)
+		}
+	}
+
+	for {
+		if cfg.HA && !isLeading(holding) {
+			log.Printf("not leader, skipping this cycle")
+			tracker.MarkStandby()
+			time.Sleep(cfg.Interval)
+			stopStandby := markStandbyWhileWaiting(tracker, cfg.Interval)
+			holding = reclaim(ctx, cfg, elector, holding)
+			stopStandby()
+			continue
+		}
+
+		start := time.Now()
+		target, err := client.Fetch(ctx, cfg.ServiceID, cfg.Interval)
+		if err != nil {
+			log.Printf("fetch error: %v", err)
+			m.GQLErrors.Inc("fetch")
+			time.Sleep(cfg.Interval)
+			continue
+		}
+		tracker.MarkSuccess()
+
+		decision := strategy.Decide(target.Signals(), target.Replicas)
+		desired := decision.Replicas
+		log.Printf("decision: %+v", decision.Trace)
+		m.DecisionLatency.Observe(time.Since(start).Seconds())
+		m.CPUAvg.Set(target.AvgCPU)
+		m.ReplicasCurrent.Set(float64(target.Replicas))
+		m.ReplicasDesired.Set(float64(desired))
+		m.CooldownRemaining.Set(cooldownRemaining(lastScale, cfg.Cooldown).Seconds())
+
+		record := audit.Record{
+			Timestamp: time.Now(),
+			ServiceID: cfg.ServiceID,
+			Inputs:    target.Signals(),
+			Replicas:  target.Replicas,
+			Desired:   desired,
+			Reason:    decision.Trace.Reason,
+		}
+
+		if desired != target.Replicas {
+			if time.Since(lastScale) > cfg.Cooldown {
+				if err := client.Scale(ctx, cfg.ServiceID, desired); err == nil {
+					lastScale = time.Now()
+					record.Scaled = true
+					m.ScaleActions.Inc(direction(target.Replicas, desired))
+				} else {
+					log.Printf("scale error: %v", err)
+					m.GQLErrors.Inc("scale")
+					record.SkippedReason = "scale error: " + err.Error()
+				}
+			} else {
+				record.SkippedReason = "cooldown"
+			}
+		}
+		auditLog.Emit(ctx, record)
+
+		if cfg.HA {
+			writeStatus(ctx, elector, target, desired, lastScale)
+		}
+		time.Sleep(cfg.Interval)
+	}
+}
+
+// cooldownRemaining is how much longer a scale action must wait, floored
+// at zero once the cooldown has elapsed.
+func cooldownRemaining(lastScale time.Time, cooldown time.Duration) time.Duration {
+	remaining := cooldown - time.Since(lastScale)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func direction(current, desired int) string {
+	if desired > current {
+		return "up"
+	}
+	return "down"
+}
+
+// markStandbyWhileWaiting periodically marks tracker alive until the
+// returned stop func is called, so a replica blocked in startCampaign
+// (which can take arbitrarily long to win leadership) stays ready
+// instead of tripping MaxAge before it ever gets a turn.
+func markStandbyWhileWaiting(tracker *health.Tracker, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tracker.MarkStandby()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				tracker.MarkStandby()
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// startCampaign builds the Backend selected by cfg.LeaderBackend, loads
+// this instance's persisted identity, and blocks until it first wins
+// leadership of cfg.ServiceID.
+func startCampaign(ctx context.Context, cfg config) (*leader.Elector, <-chan struct{}, error) {
+	id, err := leader.LoadOrCreateIdentity(cfg.IdentityPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var backend leader.Backend
+	switch cfg.LeaderBackend {
+	case "redis":
+		backend = leader.NewRedisBackend(cfg.RedisAddr)
+	case "memory", "":
+		backend = leader.NewMemoryBackend()
+	default:
+		return nil, nil, errUnknownBackend(cfg.LeaderBackend)
+	}
+
+	elector := &leader.Elector{
+		Backend: backend,
+		Key:     "autoscaler/" + cfg.ServiceID + "/leader",
+		Holder:  id,
+		TTL:     3 * cfg.Interval,
+	}
+	holding, err := elector.Campaign(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Printf("acquired leadership as %s", id)
+	return elector, holding, nil
+}
+
+// reclaim re-campaigns after a lost leadership channel closes.
+func reclaim(ctx context.Context, cfg config, elector *leader.Elector, holding <-chan struct{}) <-chan struct{} {
+	if isLeading(holding) {
+		return holding
+	}
+	fresh, err := elector.Campaign(ctx)
+	if err != nil {
+		log.Printf("leader: re-campaign failed: %v", err)
+		return holding
+	}
+	log.Printf("re-acquired leadership as %s", elector.Holder)
+	return fresh
+}
+
+func isLeading(holding <-chan struct{}) bool {
+	if holding == nil {
+		return false
+	}
+	select {
+	case <-holding:
+		return false
+	default:
+		return true
+	}
+}
+
+func writeStatus(ctx context.Context, elector *leader.Elector, target railway.Snapshot, desired int, lastScale time.Time) {
+	status := leaderStatus{
+		Holder:       elector.Holder,
+		LastDecision: desired,
+		LastScale:    lastScale,
+		Replicas:     target.Replicas,
+		UpdatedAt:    time.Now(),
+	}
+	encoded, err := json.Marshal(status)
+	if err != nil {
+		log.Printf("status marshal error: %v", err)
+		return
+	}
+	if err := elector.WriteStatus(ctx, encoded); err != nil {
+		log.Printf("status write error: %v", err)
+	}
+}
+
+type errUnknownBackend string
+
+func (e errUnknownBackend) Error() string {
+	return "unknown LEADER_BACKEND " + string(e)
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rmonvfer/autoscaler/internal/railway"
+	"github.com/rmonvfer/autoscaler/proto"
+)
+
+// Run dials cfg.ServerAddr over TLS, authenticates with cfg.Secret, and
+// then fetches metrics for every configured service on its own interval,
+// streaming samples up and executing whatever ScaleCommands come back.
+// It blocks until ctx is done or the connection drops.
+//
+// TLS is not optional: Hello carries cfg.Secret, and agents are expected
+// to dial in across the public internet to reach a NAT'd server.
+func Run(ctx context.Context, cfg Config) error {
+	tlsConfig, err := dialTLSConfig(cfg)
+	if err != nil {
+		return err
+	}
+	conn, err := tls.Dial("tcp", cfg.ServerAddr, tlsConfig)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	enc := proto.NewEncoder(conn)
+	dec := proto.NewDecoder(conn)
+	var encMu sync.Mutex
+
+	hello := proto.Frame{Kind: proto.KindHello, Hello: &proto.Hello{
+		Secret:     cfg.Secret,
+		AgentID:    cfg.AgentID,
+		ProjectID:  cfg.ProjectID,
+		ServiceIDs: cfg.ServiceIDs,
+	}}
+	if err := enc.Encode(hello); err != nil {
+		return err
+	}
+
+	ack, err := dec.Decode()
+	if err != nil || ack.Kind != proto.KindHelloAck || ack.HelloAck == nil {
+		return fmt.Errorf("agent: expected hello_ack, got %+v (err=%v)", ack, err)
+	}
+
+	client := railway.NewClient(cfg.Token)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, serviceID := range cfg.ServiceIDs {
+		interval := cfg.Interval
+		if si, ok := ack.HelloAck.ServiceIntervals[serviceID]; ok {
+			interval = si
+		}
+		wg.Add(1)
+		go func(serviceID string, interval time.Duration) {
+			defer wg.Done()
+			reportLoop(ctx, client, serviceID, interval, &encMu, enc)
+		}(serviceID, interval)
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	err = commandLoop(ctx, client, dec)
+	cancel()
+	wg.Wait()
+	return err
+}
+
+// reportLoop fetches serviceID's metrics every interval and streams them
+// as MetricSamples until ctx is done.
+func reportLoop(ctx context.Context, client *railway.Client, serviceID string, interval time.Duration, encMu *sync.Mutex, enc *proto.Encoder) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			snap, err := client.Fetch(ctx, serviceID, interval)
+			if err != nil {
+				log.Printf("agent: fetch %s: %v", serviceID, err)
+				continue
+			}
+			sample := proto.Frame{Kind: proto.KindMetricSample, MetricSample: &proto.MetricSample{
+				ServiceID: serviceID,
+				CPU:       snap.AvgCPU,
+				Replicas:  snap.Replicas,
+				Timestamp: time.Now(),
+			}}
+			encMu.Lock()
+			err = enc.Encode(sample)
+			encMu.Unlock()
+			if err != nil {
+				log.Printf("agent: send sample %s: %v", serviceID, err)
+				return
+			}
+		}
+	}
+}
+
+// commandLoop executes ScaleCommands the server sends back until the
+// connection closes or ctx is done.
+func commandLoop(ctx context.Context, client *railway.Client, dec *proto.Decoder) error {
+	for {
+		frame, err := dec.Decode()
+		if err != nil {
+			return err
+		}
+		if frame.Kind != proto.KindScaleCommand || frame.ScaleCommand == nil {
+			continue
+		}
+		cmd := frame.ScaleCommand
+		if err := client.Scale(ctx, cmd.ServiceID, cmd.Replicas); err != nil {
+			log.Printf("agent: scale %s to %d: %v", cmd.ServiceID, cmd.Replicas, err)
+		}
+	}
+}
+
+// dialTLSConfig builds the tls.Config used to verify cfg.ServerAddr. If
+// cfg.TLSCACertPath is set, the server's certificate is verified against
+// that CA instead of the system root pool, for a private/self-signed
+// autoscaler-server deployment.
+func dialTLSConfig(cfg Config) (*tls.Config, error) {
+	serverName := cfg.TLSServerName
+	if serverName == "" {
+		host, _, err := net.SplitHostPort(cfg.ServerAddr)
+		if err != nil {
+			return nil, fmt.Errorf("agent: determine TLS server name from %q: %w", cfg.ServerAddr, err)
+		}
+		serverName = host
+	}
+
+	tlsConfig := &tls.Config{ServerName: serverName}
+	if cfg.TLSCACertPath != "" {
+		pem, err := os.ReadFile(cfg.TLSCACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("agent: read TLS CA %s: %w", cfg.TLSCACertPath, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("agent: no certificates found in TLS CA %s", cfg.TLSCACertPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
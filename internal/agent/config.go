@@ -0,0 +1,86 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package agent implements the autoscaler-agent side of the agent/server
+// split: it stays stateless, fetching metrics for whatever services it's
+// told about and executing the ScaleCommands the server sends back.
+package agent
+
+import (
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// Config is read entirely from the environment, mirroring the standalone
+// binary's loadConfig.
+type Config struct {
+	ServerAddr string
+	Secret     string
+	AgentID    string
+	ProjectID  string
+	Token      string
+	ServiceIDs []string
+	Interval   time.Duration
+
+	// TLSServerName overrides the name verified against the server's
+	// certificate; defaults to the host part of ServerAddr.
+	TLSServerName string
+	// TLSCACertPath, if set, verifies the server's certificate against
+	// this CA instead of the system root pool (for a private/self-signed
+	// autoscaler-server deployment).
+	TLSCACertPath string
+}
+
+// LoadConfig reads AUTOSCALER_SERVER_ADDR, AUTOSCALER_SECRET,
+// AGENT_ID, PROJECT_ID, RAILWAY_TOKEN, SERVICE_IDS (comma-separated),
+// POLL_INTERVAL, AUTOSCALER_TLS_SERVER_NAME and AUTOSCALER_TLS_CA from
+// the environment.
+func LoadConfig() Config {
+	must := func(key string) string {
+		v := os.Getenv(key)
+		if v == "" {
+			log.Fatalf("missing env %s", key)
+		}
+		return v
+	}
+
+	var serviceIDs []string
+	for _, id := range strings.Split(must("SERVICE_IDS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			serviceIDs = append(serviceIDs, id)
+		}
+	}
+
+	interval := 30 * time.Second
+	if v := os.Getenv("POLL_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			interval = d
+		}
+	}
+
+	return Config{
+		ServerAddr:    must("AUTOSCALER_SERVER_ADDR"),
+		Secret:        must("AUTOSCALER_SECRET"),
+		AgentID:       must("AGENT_ID"),
+		ProjectID:     os.Getenv("PROJECT_ID"),
+		Token:         must("RAILWAY_TOKEN"),
+		ServiceIDs:    serviceIDs,
+		Interval:      interval,
+		TLSServerName: os.Getenv("AUTOSCALER_TLS_SERVER_NAME"),
+		TLSCACertPath: os.Getenv("AUTOSCALER_TLS_CA"),
+	}
+}
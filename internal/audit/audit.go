@@ -0,0 +1,108 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package audit emits a structured record of every scale decision, so
+// operators have more to go on than a log.Printf line, and optionally
+// forwards it to a webhook for downstream consumers (Slack bots, SIEM).
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Record is one iteration's decision, logged on every poll regardless of
+// whether a scale action was actually taken.
+type Record struct {
+	Timestamp     time.Time          `json:"timestamp"`
+	ServiceID     string             `json:"service_id"`
+	Inputs        map[string]float64 `json:"inputs"`
+	Replicas      int                `json:"replicas"`
+	Desired       int                `json:"desired"`
+	Scaled        bool               `json:"scaled"`
+	SkippedReason string             `json:"skipped_reason,omitempty"`
+	Reason        string             `json:"reason,omitempty"`
+}
+
+// Logger writes every Record as a structured JSON log line and, if
+// WebhookURL is set, POSTs it there with an HMAC-SHA256 signature over
+// the raw body in the X-Autoscaler-Signature header (hex-encoded,
+// "sha256=" prefixed, matching the GitHub webhook convention).
+type Logger struct {
+	WebhookURL    string
+	WebhookSecret string
+	HTTPClient    *http.Client
+}
+
+// NewLogger builds a Logger; an empty WebhookURL disables forwarding.
+func NewLogger(webhookURL, webhookSecret string) *Logger {
+	return &Logger{WebhookURL: webhookURL, WebhookSecret: webhookSecret, HTTPClient: http.DefaultClient}
+}
+
+// Emit logs r and, if configured, forwards it to the webhook. Webhook
+// failures are logged, not returned: audit delivery must never block or
+// fail the scaling decision it's reporting on.
+func (l *Logger) Emit(ctx context.Context, r Record) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		log.Printf("audit: marshal record: %v", err)
+		return
+	}
+	log.Printf("audit: %s", body)
+
+	if l.WebhookURL == "" {
+		return
+	}
+	if err := l.post(ctx, body); err != nil {
+		log.Printf("audit: webhook delivery failed: %v", err)
+	}
+}
+
+func (l *Logger) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Autoscaler-Signature", "sha256="+sign(body, l.WebhookSecret))
+
+	client := l.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", res.StatusCode)
+	}
+	return nil
+}
+
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
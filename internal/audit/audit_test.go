@@ -0,0 +1,66 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLogger_Emit_SignsWebhookPayload(t *testing.T) {
+	const secret = "s3cr3t"
+	var gotBody []byte
+	var gotSig string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSig = r.Header.Get("X-Autoscaler-Signature")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	l := NewLogger(srv.URL, secret)
+	record := Record{ServiceID: "srv_1", Replicas: 2, Desired: 3, Scaled: true}
+	l.Emit(context.Background(), record)
+
+	var decoded Record
+	if err := json.Unmarshal(gotBody, &decoded); err != nil {
+		t.Fatalf("webhook body did not decode as a Record: %v", err)
+	}
+	if decoded.ServiceID != "srv_1" || decoded.Desired != 3 {
+		t.Fatalf("unexpected decoded record: %+v", decoded)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(gotBody)
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if gotSig != want {
+		t.Fatalf("signature mismatch: got %s, want %s", gotSig, want)
+	}
+}
+
+func TestLogger_Emit_NoWebhookConfigured(t *testing.T) {
+	l := NewLogger("", "")
+	// Must not panic or block with no webhook configured.
+	l.Emit(context.Background(), Record{ServiceID: "srv_1"})
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package health tracks whether the control loop is still making
+// progress, so Railway's own healthchecks can kill a stuck autoscaler
+// instead of leaving it silently wedged.
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Tracker records the last time the control loop did something that
+// proves it isn't wedged: either fetch+decide succeeded, or (in HA mode)
+// it's correctly idle waiting its turn for leadership. It is safe for
+// concurrent use: the control loop reports in, the HTTP handlers read.
+type Tracker struct {
+	// MaxAge is how stale the last report can be before Ready reports
+	// not-ready.
+	MaxAge time.Duration
+
+	mu        sync.Mutex
+	lastAlive time.Time
+}
+
+// NewTracker builds a Tracker that starts "now", so a slow first fetch
+// doesn't immediately fail readiness.
+func NewTracker(maxAge time.Duration) *Tracker {
+	return &Tracker{MaxAge: maxAge, lastAlive: time.Now()}
+}
+
+// MarkSuccess records that fetch+decide just completed successfully.
+func (t *Tracker) MarkSuccess() {
+	t.mark()
+}
+
+// MarkStandby records that the control loop is correctly idle rather
+// than stuck: in HA mode, blocked acquiring or waiting to reclaim
+// leadership. Without this, every non-leader replica in an HA deployment
+// would flip not-ready after MaxAge despite working exactly as intended.
+func (t *Tracker) MarkStandby() {
+	t.mark()
+}
+
+func (t *Tracker) mark() {
+	t.mu.Lock()
+	t.lastAlive = time.Now()
+	t.mu.Unlock()
+}
+
+// Age returns how long it's been since the last successful iteration or
+// standby report.
+func (t *Tracker) Age() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return time.Since(t.lastAlive)
+}
+
+// Ready reports whether the last report is within MaxAge.
+func (t *Tracker) Ready() bool {
+	return t.Age() <= t.MaxAge
+}
+
+type statusBody struct {
+	Status  string  `json:"status"`
+	AgeSecs float64 `json:"age_seconds"`
+}
+
+// LivezHandler always reports healthy: it answers "is the process up",
+// not "is it making progress" (that's ReadyHandler).
+func (t *Tracker) LivezHandler(w http.ResponseWriter, _ *http.Request) {
+	writeStatus(w, "ok", t.Age(), http.StatusOK)
+}
+
+// ReadyHandler reports 503 once the last report (a successful fetch or a
+// standby mark) is older than MaxAge, so a wedged autoscaler gets recycled instead of silently doing
+// nothing forever.
+func (t *Tracker) ReadyHandler(w http.ResponseWriter, _ *http.Request) {
+	if t.Ready() {
+		writeStatus(w, "ok", t.Age(), http.StatusOK)
+		return
+	}
+	writeStatus(w, "stale", t.Age(), http.StatusServiceUnavailable)
+}
+
+func writeStatus(w http.ResponseWriter, status string, age time.Duration, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(statusBody{Status: status, AgeSecs: age.Seconds()})
+}
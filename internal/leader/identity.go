@@ -0,0 +1,71 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package leader
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+type identityFile struct {
+	ID string `json:"id"`
+}
+
+// LoadOrCreateIdentity reads a UUID from the JSON blob at path, creating
+// both the UUID and the file if it doesn't exist yet. The returned ID is
+// stable across restarts so that KV status entries and audit logs can
+// attribute scaling actions to a specific autoscaler instance.
+func LoadOrCreateIdentity(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		var f identityFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			return "", fmt.Errorf("leader: parse identity file %s: %w", path, err)
+		}
+		if f.ID != "" {
+			return f.ID, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("leader: read identity file %s: %w", path, err)
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		return "", fmt.Errorf("leader: generate identity: %w", err)
+	}
+	encoded, err := json.Marshal(identityFile{ID: id})
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, encoded, 0o600); err != nil {
+		return "", fmt.Errorf("leader: write identity file %s: %w", path, err)
+	}
+	return id, nil
+}
+
+// newUUID generates a random (v4) UUID without pulling in an external
+// dependency.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
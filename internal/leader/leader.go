@@ -0,0 +1,125 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package leader implements leader-election-before-acting for autoscaler
+// instances that share a SERVICE_ID, so that only one instance ever calls
+// scale() at a time.
+package leader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Backend is the pluggable KV primitive leader election is built on. Any
+// store that can offer a single-key compare-and-swap with TTL semantics
+// (Redis SET NX PX, Consul sessions, etcd leases, a Postgres advisory lock
+// table, ...) can implement it.
+type Backend interface {
+	// TryAcquire sets key to holder with the given ttl iff key is unset or
+	// already held by holder. It reports whether the caller now holds key.
+	TryAcquire(ctx context.Context, key, holder string, ttl time.Duration) (bool, error)
+
+	// Renew extends the ttl on key iff it is still held by holder. It
+	// reports whether the renewal succeeded.
+	Renew(ctx context.Context, key, holder string, ttl time.Duration) (bool, error)
+
+	// Release drops key iff it is held by holder.
+	Release(ctx context.Context, key, holder string) error
+
+	// WriteStatus stores an opaque status blob under key. It does not
+	// require ownership of any lease and is best-effort.
+	WriteStatus(ctx context.Context, key string, status []byte) error
+}
+
+// Elector campaigns for leadership of a single Key against a Backend.
+type Elector struct {
+	Backend Backend
+	Key     string
+	Holder  string
+	TTL     time.Duration
+
+	// RetryInterval controls how often a non-leader retries acquisition,
+	// and how often the leader renews its lease. Defaults to TTL/3.
+	RetryInterval time.Duration
+}
+
+// Campaign blocks until it acquires leadership of e.Key (or ctx is done),
+// then returns a channel that stays open for as long as leadership is
+// held and is closed the moment it is lost (renewal failure, backend
+// error, or ctx cancellation). Callers must stop acting as leader as soon
+// as the channel closes.
+func (e *Elector) Campaign(ctx context.Context) (<-chan struct{}, error) {
+	interval := e.RetryInterval
+	if interval <= 0 {
+		interval = e.TTL / 3
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("leader: TTL and RetryInterval both unset")
+	}
+
+	for {
+		ok, err := e.Backend.TryAcquire(ctx, e.Key, e.Holder, e.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("leader: acquire %s: %w", e.Key, err)
+		}
+		if ok {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+
+	lost := make(chan struct{})
+	go e.renewLoop(ctx, interval, lost)
+	return lost, nil
+}
+
+func (e *Elector) renewLoop(ctx context.Context, interval time.Duration, lost chan struct{}) {
+	defer close(lost)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			_ = e.Backend.Release(context.Background(), e.Key, e.Holder)
+			return
+		case <-ticker.C:
+			ok, err := e.Backend.Renew(ctx, e.Key, e.Holder, e.TTL)
+			if err != nil || !ok {
+				return
+			}
+		}
+	}
+}
+
+// statusGuard serializes WriteStatus calls across all Electors (one lock
+// for every key) so concurrent callers (rare, but cheap to guard against)
+// don't interleave writes.
+var statusGuard sync.Mutex
+
+// WriteStatus is a convenience wrapper around e.Backend.WriteStatus that
+// serializes access; the autoscaler calls it once per loop iteration.
+func (e *Elector) WriteStatus(ctx context.Context, status []byte) error {
+	statusGuard.Lock()
+	defer statusGuard.Unlock()
+	return e.Backend.WriteStatus(ctx, e.Key+":status", status)
+}
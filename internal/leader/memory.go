@@ -0,0 +1,85 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package leader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryLease struct {
+	holder string
+	expiry time.Time
+}
+
+// MemoryBackend is an in-process Backend implementation. It holds no
+// state across process restarts and is meant for tests and single-process
+// development, not production HA.
+type MemoryBackend struct {
+	mu     sync.Mutex
+	leases map[string]memoryLease
+	status map[string][]byte
+}
+
+// NewMemoryBackend returns a ready-to-use MemoryBackend.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		leases: make(map[string]memoryLease),
+		status: make(map[string][]byte),
+	}
+}
+
+func (b *MemoryBackend) TryAcquire(_ context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if l, ok := b.leases[key]; ok && l.holder != holder && now.Before(l.expiry) {
+		return false, nil
+	}
+	b.leases[key] = memoryLease{holder: holder, expiry: now.Add(ttl)}
+	return true, nil
+}
+
+func (b *MemoryBackend) Renew(_ context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	l, ok := b.leases[key]
+	if !ok || l.holder != holder {
+		return false, nil
+	}
+	b.leases[key] = memoryLease{holder: holder, expiry: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (b *MemoryBackend) Release(_ context.Context, key, holder string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if l, ok := b.leases[key]; ok && l.holder == holder {
+		delete(b.leases, key)
+	}
+	return nil
+}
+
+func (b *MemoryBackend) WriteStatus(_ context.Context, key string, status []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.status[key] = append([]byte(nil), status...)
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package leader
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// RedisBackend is a Backend implementation over a single Redis connection,
+// speaking just enough RESP to avoid pulling in a client dependency: SET
+// with NX/PX, and EVAL for the compare-and-expire used by Renew and the
+// compare-and-delete used by Release.
+// It is not safe for concurrent use by multiple Electors; callers that
+// need that should open one RedisBackend per Elector.
+type RedisBackend struct {
+	addr    string
+	dialer  net.Dialer
+	timeout time.Duration
+
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// NewRedisBackend dials addr (host:port) lazily on first use.
+func NewRedisBackend(addr string) *RedisBackend {
+	return &RedisBackend{addr: addr, timeout: 5 * time.Second}
+}
+
+func (b *RedisBackend) ensureConn(ctx context.Context) error {
+	if b.conn != nil {
+		return nil
+	}
+	conn, err := b.dialer.DialContext(ctx, "tcp", b.addr)
+	if err != nil {
+		return fmt.Errorf("redis: dial %s: %w", b.addr, err)
+	}
+	b.conn = conn
+	b.r = bufio.NewReader(conn)
+	return nil
+}
+
+func (b *RedisBackend) do(ctx context.Context, args ...string) (interface{}, error) {
+	if err := b.ensureConn(ctx); err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = b.conn.SetDeadline(deadline)
+	} else {
+		_ = b.conn.SetDeadline(time.Now().Add(b.timeout))
+	}
+
+	if err := writeRESPCommand(b.conn, args); err != nil {
+		b.closeOnErr()
+		return nil, err
+	}
+	reply, err := readRESPReply(b.r)
+	if err != nil {
+		b.closeOnErr()
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (b *RedisBackend) closeOnErr() {
+	if b.conn != nil {
+		_ = b.conn.Close()
+	}
+	b.conn, b.r = nil, nil
+}
+
+func (b *RedisBackend) TryAcquire(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	reply, err := b.do(ctx, "SET", key, holder, "NX", "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil {
+		return false, err
+	}
+	if _, ok := reply.(string); ok {
+		return true, nil
+	}
+	// NX failed because the key exists; it may still be our own lease, in
+	// which case this call behaves like a renew.
+	return b.Renew(ctx, key, holder, ttl)
+}
+
+func (b *RedisBackend) Renew(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	script := `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("PEXPIRE", KEYS[1], ARGV[2]) else return 0 end`
+	reply, err := b.do(ctx, "EVAL", script, "1", key, holder, strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil {
+		return false, err
+	}
+	n, _ := reply.(int64)
+	return n == 1, nil
+}
+
+func (b *RedisBackend) Release(ctx context.Context, key, holder string) error {
+	script := `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) else return 0 end`
+	_, err := b.do(ctx, "EVAL", script, "1", key, holder)
+	return err
+}
+
+func (b *RedisBackend) WriteStatus(ctx context.Context, key string, status []byte) error {
+	_, err := b.do(ctx, "SET", key, string(status))
+	return err
+}
+
+// writeRESPCommand encodes args as a RESP array of bulk strings.
+func writeRESPCommand(w net.Conn, args []string) error {
+	buf := fmt.Sprintf("*%d\r\n", len(args))
+	for _, a := range args {
+		buf += fmt.Sprintf("$%d\r\n%s\r\n", len(a), a)
+	}
+	_, err := w.Write([]byte(buf))
+	return err
+}
+
+// readRESPReply parses a single RESP reply into a string, int64, nil or
+// error, enough to support the SET and EVAL replies used above.
+func readRESPReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 {
+		return nil, fmt.Errorf("redis: short reply %q", line)
+	}
+	prefix, body := line[0], line[1:len(line)-2]
+
+	switch prefix {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, fmt.Errorf("redis: %s", body)
+	case ':':
+		n, err := strconv.ParseInt(body, 10, 64)
+		return n, err
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return string(data[:n]), nil
+	default:
+		return nil, fmt.Errorf("redis: unsupported reply prefix %q", prefix)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package metrics exposes the autoscaler's own Prometheus metrics. It
+// implements just enough of the text exposition format by hand to avoid
+// pulling in client_golang for a handful of gauges and counters.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// Metrics holds every series the autoscaler reports, named per the
+// operator-facing contract: autoscaler_cpu_avg, autoscaler_replicas_*,
+// autoscaler_scale_actions_total{direction}, autoscaler_gql_errors_total{kind},
+// autoscaler_cooldown_remaining_seconds and autoscaler_decision_latency_seconds.
+type Metrics struct {
+	CPUAvg            *Gauge
+	ReplicasCurrent   *Gauge
+	ReplicasDesired   *Gauge
+	CooldownRemaining *Gauge
+	ScaleActions      *CounterVec
+	GQLErrors         *CounterVec
+	DecisionLatency   *Histogram
+}
+
+// New builds an empty Metrics ready to be updated and served.
+func New() *Metrics {
+	return &Metrics{
+		CPUAvg:            &Gauge{},
+		ReplicasCurrent:   &Gauge{},
+		ReplicasDesired:   &Gauge{},
+		CooldownRemaining: &Gauge{},
+		ScaleActions:      &CounterVec{},
+		GQLErrors:         &CounterVec{},
+		DecisionLatency:   NewHistogram([]float64{0.01, 0.05, 0.1, 0.5, 1, 5}),
+	}
+}
+
+// ServeHTTP writes every series in Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	writeGauge(w, "autoscaler_cpu_avg", "Average CPU percent across instances at last fetch.", m.CPUAvg.Get())
+	writeGauge(w, "autoscaler_replicas_current", "Replica count observed at last fetch.", m.ReplicasCurrent.Get())
+	writeGauge(w, "autoscaler_replicas_desired", "Replica count the active strategy last decided on.", m.ReplicasDesired.Get())
+	writeGauge(w, "autoscaler_cooldown_remaining_seconds", "Seconds left before another scale action is allowed.", m.CooldownRemaining.Get())
+	writeCounterVec(w, "autoscaler_scale_actions_total", "direction", "Scale actions taken, by direction.", m.ScaleActions)
+	writeCounterVec(w, "autoscaler_gql_errors_total", "kind", "GraphQL call failures, by classification.", m.GQLErrors)
+	writeHistogram(w, "autoscaler_decision_latency_seconds", "Time to fetch metrics and reach a scale decision.", m.DecisionLatency)
+}
+
+func writeGauge(w io.Writer, name, help string, value float64) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %v\n", name, help, name, name, value)
+}
+
+func writeCounterVec(w io.Writer, name, label, help string, cv *CounterVec) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	for _, k := range cv.labels() {
+		fmt.Fprintf(w, "%s{%s=%q} %v\n", name, label, k, cv.Get(k))
+	}
+}
+
+func writeHistogram(w io.Writer, name, help string, h *Histogram) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	cumulative := uint64(0)
+	counts, sum, count := h.Snapshot()
+	for i, bound := range h.buckets {
+		cumulative += counts[i]
+		fmt.Fprintf(w, "%s_bucket{le=%q} %d\n", name, formatBound(bound), cumulative)
+	}
+	cumulative += counts[len(h.buckets)]
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", name, cumulative)
+	fmt.Fprintf(w, "%s_sum %v\n", name, sum)
+	fmt.Fprintf(w, "%s_count %d\n", name, count)
+}
+
+func formatBound(f float64) string {
+	return fmt.Sprintf("%g", f)
+}
+
+// Gauge is a single float64 value safe for concurrent use.
+type Gauge struct {
+	mu    sync.Mutex
+	value float64
+}
+
+func (g *Gauge) Set(v float64) {
+	g.mu.Lock()
+	g.value = v
+	g.mu.Unlock()
+}
+
+func (g *Gauge) Get() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.value
+}
+
+// CounterVec is a set of monotonically increasing counters keyed by a
+// single label value (e.g. "up"/"down", or a gql error classification).
+type CounterVec struct {
+	mu     sync.Mutex
+	counts map[string]float64
+}
+
+func (c *CounterVec) Inc(label string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[string]float64)
+	}
+	c.counts[label]++
+}
+
+func (c *CounterVec) Get(label string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[label]
+}
+
+func (c *CounterVec) labels() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0, len(c.counts))
+	for k := range c.counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Histogram is a fixed-bucket histogram safe for concurrent use.
+type Histogram struct {
+	buckets []float64
+
+	mu     sync.Mutex
+	counts []uint64 // len(buckets)+1, last bucket is the +Inf overflow
+	sum    float64
+	count  uint64
+}
+
+// NewHistogram builds a Histogram with the given upper bucket bounds,
+// which must be sorted ascending.
+func NewHistogram(buckets []float64) *Histogram {
+	return &Histogram{buckets: buckets, counts: make([]uint64, len(buckets)+1)}
+}
+
+func (h *Histogram) Observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sum += v
+	h.count++
+	for i, bound := range h.buckets {
+		if v <= bound {
+			h.counts[i]++
+			return
+		}
+	}
+	h.counts[len(h.buckets)]++
+}
+
+func (h *Histogram) Snapshot() (counts []uint64, sum float64, count uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	counts = make([]uint64, len(h.counts))
+	copy(counts, h.counts)
+	return counts, h.sum, h.count
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServeHTTP_RendersAllSeries(t *testing.T) {
+	m := New()
+	m.CPUAvg.Set(42.5)
+	m.ReplicasCurrent.Set(3)
+	m.ScaleActions.Inc("up")
+	m.ScaleActions.Inc("up")
+	m.GQLErrors.Inc("timeout")
+	m.DecisionLatency.Observe(0.2)
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		"autoscaler_cpu_avg 42.5",
+		"autoscaler_replicas_current 3",
+		`autoscaler_scale_actions_total{direction="up"} 2`,
+		`autoscaler_gql_errors_total{kind="timeout"} 1`,
+		"autoscaler_decision_latency_seconds_count 1",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected metrics output to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestHistogram_SnapshotReturnsPerBucketCounts(t *testing.T) {
+	h := NewHistogram([]float64{1, 5})
+	h.Observe(0.5) // falls in the <=1 bucket
+	h.Observe(3)   // falls in the <=5 bucket
+	h.Observe(10)  // overflows into +Inf
+
+	counts, sum, count := h.Snapshot()
+	if counts[0] != 1 || counts[1] != 1 || counts[2] != 1 {
+		t.Fatalf("expected one observation per bucket, got %v", counts)
+	}
+	if count != 3 || sum != 13.5 {
+		t.Fatalf("unexpected count/sum: %d %v", count, sum)
+	}
+}
+
+func TestServeHTTP_HistogramBucketsRenderCumulative(t *testing.T) {
+	m := New()
+	m.DecisionLatency.Observe(0.02) // <=0.05 and every larger bucket
+	m.DecisionLatency.Observe(2)    // only <=5 and +Inf
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+
+	if !strings.Contains(body, `autoscaler_decision_latency_seconds_bucket{le="5"} 2`) {
+		t.Fatalf("expected the <=5 bucket to cumulatively include both observations, got:\n%s", body)
+	}
+	if !strings.Contains(body, `autoscaler_decision_latency_seconds_bucket{le="0.5"} 1`) {
+		t.Fatalf("expected the <=0.5 bucket to include only the smaller observation, got:\n%s", body)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// Recorder wraps a Strategy and remembers its most recent Trace, for
+// structured logging and the optional /debug HTTP endpoint.
+type Recorder struct {
+	Strategy
+
+	mu   sync.RWMutex
+	last Trace
+}
+
+// NewRecorder wraps strategy so every Decide call updates the trace
+// available from Last and ServeHTTP.
+func NewRecorder(strategy Strategy) *Recorder {
+	return &Recorder{Strategy: strategy}
+}
+
+func (r *Recorder) Decide(signals Signals, replicas int) Decision {
+	d := r.Strategy.Decide(signals, replicas)
+	r.mu.Lock()
+	r.last = d.Trace
+	r.mu.Unlock()
+	return d
+}
+
+// Last returns the most recent Trace recorded by Decide.
+func (r *Recorder) Last() Trace {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.last
+}
+
+// ServeHTTP serves the most recent Trace as JSON, suitable for mounting
+// at /debug.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(r.Last())
+}
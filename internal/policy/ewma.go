@@ -0,0 +1,134 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"sync"
+)
+
+// EWMAParams configures an EWMAStrategy. High/Low/Weights are keyed by
+// signal name (e.g. "cpu", "memory", "network"); a signal absent from
+// High and Low is smoothed but doesn't vote.
+type EWMAParams struct {
+	Alpha     float64 // smoothing factor, default 0.3
+	High, Low map[string]float64
+	Weights   map[string]float64
+	Reducer   Reducer
+	Min, Max  int
+	StatePath string // optional; persists smoothed state across restarts
+}
+
+type ewmaState struct {
+	Smoothed Signals `json:"smoothed"`
+	Replicas int     `json:"replicas"`
+}
+
+// EWMAStrategy maintains s_t = alpha*x_t + (1-alpha)*s_{t-1} per signal
+// and votes to scale when the smoothed value crosses High/Low, combining
+// multiple signals' votes via Reducer. State resets whenever the observed
+// replica count changes out from under it (a manual scale, or another
+// instance's decision).
+type EWMAStrategy struct {
+	params EWMAParams
+
+	mu       sync.Mutex
+	smoothed Signals
+	replicas int
+	loaded   bool
+}
+
+// NewEWMAStrategy builds an EWMAStrategy, loading any persisted state
+// from params.StatePath. A missing or unreadable state file just starts
+// from a clean slate.
+func NewEWMAStrategy(params EWMAParams) *EWMAStrategy {
+	if params.Alpha <= 0 {
+		params.Alpha = 0.3
+	}
+	s := &EWMAStrategy{params: params}
+	if st, err := loadState[ewmaState](params.StatePath); err == nil {
+		s.smoothed = st.Smoothed
+		s.replicas = st.Replicas
+		s.loaded = true
+	}
+	return s
+}
+
+func (s *EWMAStrategy) Decide(signals Signals, replicas int) Decision {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loaded && s.replicas != replicas {
+		s.smoothed = nil // replica count moved outside our control; forget old smoothing
+	}
+	s.loaded = true
+	if s.smoothed == nil {
+		s.smoothed = make(Signals, len(signals))
+	}
+
+	votes := make(map[string]float64, len(signals))
+	for name, value := range signals {
+		prev, ok := s.smoothed[name]
+		if !ok {
+			prev = value
+		}
+		smoothed := s.params.Alpha*value + (1-s.params.Alpha)*prev
+		s.smoothed[name] = smoothed
+
+		high, hasHigh := s.params.High[name]
+		low, hasLow := s.params.Low[name]
+		switch {
+		case hasHigh && smoothed > high:
+			votes[name] = 1
+		case hasLow && smoothed < low:
+			votes[name] = -1
+		case hasHigh || hasLow:
+			votes[name] = 0 // a holding signal still counts, so it can block a ReducerMax scale-down
+		}
+	}
+
+	direction := s.params.Reducer.combine(votes, s.params.Weights)
+	desired := replicas
+	reason := "hold"
+	switch {
+	case direction > 0 && replicas < s.params.Max:
+		desired = replicas + 1
+		reason = "smoothed signal(s) above high threshold"
+	case direction < 0 && replicas > s.params.Min:
+		desired = replicas - 1
+		reason = "smoothed signal(s) below low threshold"
+	}
+
+	s.replicas = replicas
+	_ = saveState(s.params.StatePath, ewmaState{Smoothed: s.smoothed, Replicas: replicas})
+
+	return Decision{
+		Replicas: desired,
+		Trace: Trace{
+			Inputs:   signals,
+			Smoothed: cloneSignals(s.smoothed),
+			Output:   float64(direction),
+			Reason:   reason,
+		},
+	}
+}
+
+func cloneSignals(s Signals) Signals {
+	out := make(Signals, len(s))
+	for k, v := range s {
+		out[k] = v
+	}
+	return out
+}
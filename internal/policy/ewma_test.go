@@ -0,0 +1,122 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package policy
+
+import "testing"
+
+func TestEWMAStrategy_SmoothsOutASingleSpike(t *testing.T) {
+	s := NewEWMAStrategy(EWMAParams{
+		Alpha: 0.3,
+		High:  map[string]float64{"cpu": 80},
+		Low:   map[string]float64{"cpu": 20},
+		Min:   1, Max: 5,
+	})
+
+	// Steady at 50, then one spike to 95: the spike alone shouldn't push
+	// the smoothed value over 80.
+	for i := 0; i < 5; i++ {
+		s.Decide(Signals{"cpu": 50}, 2)
+	}
+	d := s.Decide(Signals{"cpu": 95}, 2)
+	if d.Replicas != 2 {
+		t.Fatalf("single spike should not trigger scale-up, got replicas=%d trace=%+v", d.Replicas, d.Trace)
+	}
+}
+
+func TestEWMAStrategy_ScalesUpOnSustainedHighSignal(t *testing.T) {
+	s := NewEWMAStrategy(EWMAParams{
+		Alpha: 0.5,
+		High:  map[string]float64{"cpu": 80},
+		Low:   map[string]float64{"cpu": 20},
+		Min:   1, Max: 5,
+	})
+
+	var last Decision
+	replicas := 2
+	for i := 0; i < 10; i++ {
+		last = s.Decide(Signals{"cpu": 95}, replicas)
+		replicas = last.Replicas
+	}
+	if last.Replicas <= 2 {
+		t.Fatalf("sustained high cpu should eventually scale up, got %d", last.Replicas)
+	}
+}
+
+func TestEWMAStrategy_ResetsStateWhenReplicasChangeExternally(t *testing.T) {
+	s := NewEWMAStrategy(EWMAParams{Alpha: 0.5, High: map[string]float64{"cpu": 80}, Min: 1, Max: 5})
+	s.Decide(Signals{"cpu": 90}, 2)
+
+	if s.smoothed["cpu"] == 0 {
+		t.Fatalf("expected smoothed state to be populated")
+	}
+
+	// Someone else scaled the service to 4 behind our back.
+	s.Decide(Signals{"cpu": 10}, 4)
+	if s.smoothed["cpu"] != 10 {
+		t.Fatalf("expected smoothing to reset to the fresh reading, got %v", s.smoothed["cpu"])
+	}
+}
+
+func TestEWMAStrategy_KeepsSmoothingWhenCooldownPinsReplicas(t *testing.T) {
+	// The caller (the standalone loop) only ever actuates a decision when
+	// cooldown allows it, so a blocked scale means Decide is fed the same
+	// observed replica count every call even though its own last decision
+	// wanted to scale up. Smoothing must key off that observed count, not
+	// its own prior output, or it resets every iteration and never
+	// converges.
+	// High is set low enough that every call after the first (cpu 30)
+	// votes to scale up, so the
+	// decision (replicas+1) always differs from the observed replica
+	// count the pinned caller keeps passing in. With the old
+	// reset-on-desired bug that mismatch wipes smoothing every call.
+	s := NewEWMAStrategy(EWMAParams{Alpha: 0.5, High: map[string]float64{"cpu": 56}, Min: 1, Max: 5})
+	s.Decide(Signals{"cpu": 30}, 2)
+	for i := 0; i < 5; i++ {
+		s.Decide(Signals{"cpu": 95}, 2)
+	}
+	// Blending 30 -> 95 at alpha=0.5 for 5 steps converges well above the
+	// midpoint; a reset-every-call bug would instead pin it at 95.
+	if s.smoothed["cpu"] == 95 {
+		t.Fatalf("expected smoothing to blend gradually, got it pinned at the raw reading: %v", s.smoothed["cpu"])
+	}
+}
+
+func TestReducer_MaxScalesUpOnAnySignal(t *testing.T) {
+	votes := map[string]float64{"cpu": 0, "memory": 1}
+	if got := ReducerMax.combine(votes, nil); got != 1 {
+		t.Fatalf("expected max reducer to scale up, got %d", got)
+	}
+}
+
+func TestReducer_MaxScalesDownOnlyWhenAllVoteDown(t *testing.T) {
+	votes := map[string]float64{"cpu": -1, "memory": 0}
+	if got := ReducerMax.combine(votes, nil); got != 0 {
+		t.Fatalf("expected max reducer to hold when not all signals vote down, got %d", got)
+	}
+
+	votes = map[string]float64{"cpu": -1, "memory": -1}
+	if got := ReducerMax.combine(votes, nil); got != -1 {
+		t.Fatalf("expected max reducer to scale down when all signals vote down, got %d", got)
+	}
+}
+
+func TestReducer_WeightedSum(t *testing.T) {
+	votes := map[string]float64{"cpu": 1, "memory": -1}
+	weights := map[string]float64{"cpu": 2, "memory": 1}
+	if got := ReducerWeightedSum.combine(votes, weights); got != 1 {
+		t.Fatalf("expected weighted cpu vote to win, got %d", got)
+	}
+}
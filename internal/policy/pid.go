@@ -0,0 +1,129 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// PIDParams configures a PIDStrategy. Signal defaults to "cpu". Target is
+// the setpoint the controller drives Signal towards by adjusting replica
+// count; IntegralClamp bounds the integral term (anti-windup); RateLimit
+// caps how many replicas a single decision can add or remove (default 1).
+type PIDParams struct {
+	Kp, Ki, Kd    float64
+	Signal        string
+	Target        float64
+	Min, Max      int
+	IntegralClamp float64
+	RateLimit     int
+	StatePath     string
+}
+
+type pidState struct {
+	Integral  float64 `json:"integral"`
+	PrevError float64 `json:"prevError"`
+	Replicas  int     `json:"replicas"`
+}
+
+// PIDStrategy drives Signal towards Target by treating replica count as
+// the control variable: error = Target - signal, and since adding
+// replicas reduces per-replica load, the controller output is applied
+// with the opposite sign of a textbook PID (more replicas when the
+// signal runs hot).
+type PIDStrategy struct {
+	params PIDParams
+
+	mu        sync.Mutex
+	integral  float64
+	prevError float64
+	replicas  int
+	loaded    bool
+}
+
+// NewPIDStrategy builds a PIDStrategy, loading persisted integral/error
+// state from params.StatePath if present.
+func NewPIDStrategy(params PIDParams) *PIDStrategy {
+	if params.Signal == "" {
+		params.Signal = "cpu"
+	}
+	if params.RateLimit <= 0 {
+		params.RateLimit = 1
+	}
+	s := &PIDStrategy{params: params}
+	if st, err := loadState[pidState](params.StatePath); err == nil {
+		s.integral = st.Integral
+		s.prevError = st.PrevError
+		s.replicas = st.Replicas
+		s.loaded = true
+	}
+	return s
+}
+
+func (s *PIDStrategy) Decide(signals Signals, replicas int) Decision {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loaded && s.replicas != replicas {
+		s.integral, s.prevError = 0, 0 // replica count moved outside our control; forget accumulated error
+	}
+	s.loaded = true
+
+	value := signals[s.params.Signal]
+	errVal := s.params.Target - value
+
+	s.integral += errVal
+	if clamp := s.params.IntegralClamp; clamp > 0 {
+		s.integral = math.Max(-clamp, math.Min(clamp, s.integral))
+	}
+	derivative := errVal - s.prevError
+	s.prevError = errVal
+
+	output := -(s.params.Kp*errVal + s.params.Ki*s.integral + s.params.Kd*derivative)
+	delta := int(math.Round(output))
+	if delta > s.params.RateLimit {
+		delta = s.params.RateLimit
+	}
+	if delta < -s.params.RateLimit {
+		delta = -s.params.RateLimit
+	}
+
+	desired := clampInt(replicas+delta, s.params.Min, s.params.Max)
+	s.replicas = replicas
+	_ = saveState(s.params.StatePath, pidState{Integral: s.integral, PrevError: s.prevError, Replicas: replicas})
+
+	return Decision{
+		Replicas: desired,
+		Trace: Trace{
+			Inputs: signals,
+			Error:  errVal,
+			Output: output,
+			Reason: fmt.Sprintf("pid error=%.2f output=%.2f delta=%d", errVal, output, delta),
+		},
+	}
+}
+
+func clampInt(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package policy
+
+import "testing"
+
+func TestPIDStrategy_ScalesUpWhenSignalAboveTarget(t *testing.T) {
+	s := NewPIDStrategy(PIDParams{Kp: 0.1, Target: 50, Min: 1, Max: 10, RateLimit: 1})
+	d := s.Decide(Signals{"cpu": 80}, 2)
+	if d.Replicas != 3 {
+		t.Fatalf("expected scale up by 1 (rate limited), got %d, trace=%+v", d.Replicas, d.Trace)
+	}
+}
+
+func TestPIDStrategy_RateLimitCapsASingleDecision(t *testing.T) {
+	s := NewPIDStrategy(PIDParams{Kp: 10, Target: 0, Min: 1, Max: 20, RateLimit: 2})
+	d := s.Decide(Signals{"cpu": 100}, 2)
+	if d.Replicas != 4 {
+		t.Fatalf("expected rate limit to cap the jump to +2, got %d", d.Replicas)
+	}
+}
+
+func TestPIDStrategy_ClampsToMinMax(t *testing.T) {
+	s := NewPIDStrategy(PIDParams{Kp: 10, Target: 0, Min: 1, Max: 3, RateLimit: 5})
+	d := s.Decide(Signals{"cpu": 100}, 2)
+	if d.Replicas != 3 {
+		t.Fatalf("expected clamp to Max=3, got %d", d.Replicas)
+	}
+}
+
+func TestPIDStrategy_IntegralAntiWindup(t *testing.T) {
+	s := NewPIDStrategy(PIDParams{Ki: 1, Target: 0, Min: 1, Max: 100, IntegralClamp: 5, RateLimit: 100})
+	for i := 0; i < 20; i++ {
+		s.Decide(Signals{"cpu": 50}, 1)
+	}
+	if s.integral > 5 || s.integral < -5 {
+		t.Fatalf("expected integral to stay within the anti-windup clamp, got %v", s.integral)
+	}
+}
+
+func TestPIDStrategy_ResetsOnExternalReplicaChange(t *testing.T) {
+	s := NewPIDStrategy(PIDParams{Ki: 1, Target: 0, Min: 1, Max: 100, RateLimit: 100})
+	s.Decide(Signals{"cpu": 10}, 2)
+	if s.integral == 0 {
+		t.Fatalf("expected integral to accumulate")
+	}
+	s.Decide(Signals{"cpu": 10}, 9) // someone else scaled us to 9
+	if s.integral != -10 {
+		t.Fatalf("expected integral to reset and accumulate only the latest error, got %v", s.integral)
+	}
+}
+
+func TestPIDStrategy_KeepsIntegralWhenCooldownPinsReplicas(t *testing.T) {
+	// Same cooldown scenario as the EWMA equivalent: the observed replica
+	// count stays pinned across calls even though the controller itself
+	// wants to scale, so the external-change check must key off the
+	// observed count, not the decision's own output.
+	s := NewPIDStrategy(PIDParams{Ki: 1, Target: 0, Min: 1, Max: 100, RateLimit: 100})
+	for i := 0; i < 5; i++ {
+		s.Decide(Signals{"cpu": 10}, 2)
+	}
+	if s.integral != -50 {
+		t.Fatalf("expected integral to accumulate across pinned-replica calls, got %v", s.integral)
+	}
+}
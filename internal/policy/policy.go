@@ -0,0 +1,49 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package policy holds the scale-decision logic shared by the standalone
+// autoscaler and autoscaler-server: a simple Threshold strategy plus
+// EWMA-smoothed and PID-controlled strategies for noisier, multi-signal
+// deployments.
+package policy
+
+// Signals is a named set of metric readings for one decision, e.g.
+// {"cpu": 42.5, "memory": 61.0, "network": 1.2e6}.
+type Signals map[string]float64
+
+// Trace records why a Strategy reached a decision, for structured logs
+// and the /debug endpoint.
+type Trace struct {
+	Inputs   Signals `json:"inputs"`
+	Smoothed Signals `json:"smoothed,omitempty"`
+	Error    float64 `json:"error,omitempty"`
+	Output   float64 `json:"output"`
+	Reason   string  `json:"reason"`
+}
+
+// Decision is a Strategy's verdict: the desired replica count, with the
+// Trace that justifies it.
+type Decision struct {
+	Replicas int
+	Trace    Trace
+}
+
+// Strategy turns a Signals reading and the current replica count into a
+// Decision. Implementations may hold smoothing/integral state across
+// calls and must reset it when Replicas changes out from under them
+// (e.g. a manual scale, or another instance's decision taking effect).
+type Strategy interface {
+	Decide(signals Signals, replicas int) Decision
+}
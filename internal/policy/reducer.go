@@ -0,0 +1,72 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package policy
+
+// Reducer combines one vote per signal (+1 scale up, -1 scale down, 0
+// hold) into a single direction.
+type Reducer string
+
+const (
+	// ReducerMax scales up if any signal votes up (e.g. high memory *or*
+	// high CPU triggers scale-up), and only scales down if every signal
+	// votes down.
+	ReducerMax Reducer = "max"
+
+	// ReducerWeightedSum scales by the sign of the weighted sum of votes,
+	// so a single noisy signal can be outvoted by the rest.
+	ReducerWeightedSum Reducer = "weighted_sum"
+)
+
+// combine applies r to votes (signal name -> vote in {-1, 0, 1}) using
+// weights (signal name -> weight, defaulting to 1 when absent).
+func (r Reducer) combine(votes map[string]float64, weights map[string]float64) int {
+	switch r {
+	case ReducerWeightedSum:
+		sum := 0.0
+		for signal, vote := range votes {
+			w := weights[signal]
+			if w == 0 {
+				w = 1
+			}
+			sum += vote * w
+		}
+		switch {
+		case sum > 0:
+			return 1
+		case sum < 0:
+			return -1
+		default:
+			return 0
+		}
+	default: // ReducerMax and unset
+		if len(votes) == 0 {
+			return 0
+		}
+		allDown := true
+		for _, vote := range votes {
+			if vote > 0 {
+				return 1
+			}
+			if vote >= 0 {
+				allDown = false
+			}
+		}
+		if allDown {
+			return -1
+		}
+		return 0
+	}
+}
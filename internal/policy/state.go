@@ -0,0 +1,53 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// loadState reads and decodes a JSON state file. An empty path or a
+// missing file is reported as an error so callers fall back to a zero
+// value without distinguishing "not configured" from "first run".
+func loadState[T any](path string) (T, error) {
+	var state T
+	if path == "" {
+		return state, fmt.Errorf("policy: no state path configured")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, err
+	}
+	return state, nil
+}
+
+// saveState writes state as JSON to path. A no-op when path is empty, so
+// strategies work fine without persistence configured.
+func saveState[T any](path string, state T) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
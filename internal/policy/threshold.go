@@ -0,0 +1,64 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package policy
+
+// ThresholdParams mirrors the CPU_HIGH/CPU_LOW/MIN_REPLICAS/MAX_REPLICAS
+// knobs the standalone binary has always exposed.
+type ThresholdParams struct {
+	High, Low float64
+	Min, Max  int
+}
+
+// Decide returns the desired replica count for a flat CPU average: one
+// more past High while below Max, one fewer below Low while above Min,
+// otherwise hold. A count already outside [Min, Max] is not pulled back in.
+func Decide(cpu float64, replicas int, p ThresholdParams) int {
+	switch {
+	case cpu > p.High && replicas < p.Max:
+		return replicas + 1
+	case cpu < p.Low && replicas > p.Min:
+		return replicas - 1
+	default:
+		return replicas
+	}
+}
+
+// ThresholdStrategy adapts Decide to the Strategy interface, reading the
+// "cpu" signal, so callers that want EWMA or PID smoothing can swap
+// strategies without changing their call site.
+type ThresholdStrategy struct {
+	Params ThresholdParams
+}
+
+func (s ThresholdStrategy) Decide(signals Signals, replicas int) Decision {
+	cpu := signals["cpu"]
+	desired := Decide(cpu, replicas, s.Params)
+	reason := "hold"
+	switch {
+	case desired > replicas:
+		reason = "cpu above high threshold"
+	case desired < replicas:
+		reason = "cpu below low threshold"
+	}
+	return Decision{
+		Replicas: desired,
+		Trace: Trace{
+			Inputs: signals,
+			Output: cpu,
+			Reason: reason,
+		},
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package railway wraps the handful of Railway GraphQL calls the
+// autoscaler needs (read metrics, read/write replica count) behind a
+// Client, so the standalone binary and the agent/server split can share
+// the same fetch/scale behavior, including the retrying transport.
+package railway
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rmonvfer/autoscaler/internal/policy"
+	"github.com/rmonvfer/autoscaler/internal/transport"
+)
+
+const endpoint = "https://backboard.railway.com/graphql/v2"
+
+type metricPoint struct {
+	Cpu       float64 `json:"cpuPercent"`
+	Memory    float64 `json:"memoryPercent"`
+	NetworkRx float64 `json:"networkRxBytes"`
+}
+
+type instanceMetrics struct {
+	Metrics []metricPoint `json:"metrics"`
+}
+
+// minimal pieces of the response payload
+// service(id) { instances { metrics { cpuPercent memoryPercent networkRxBytes } } replicas }
+type serviceData struct {
+	Instances []instanceMetrics `json:"instances"`
+	Replicas  int               `json:"replicas"`
+}
+
+type serviceResp struct {
+	Service serviceData `json:"service"`
+}
+
+type gqlResponse struct {
+	Data   serviceResp                `json:"data"`
+	Errors []struct{ Message string } `json:"errors"`
+}
+
+// Snapshot is a metrics reading for one service at one point in time.
+type Snapshot struct {
+	AvgCPU       float64
+	AvgMemory    float64
+	AvgNetworkRx float64
+	Replicas     int
+}
+
+// Signals adapts a Snapshot to policy.Signals so it can be fed directly
+// to any policy.Strategy.
+func (s Snapshot) Signals() policy.Signals {
+	return policy.Signals{"cpu": s.AvgCPU, "memory": s.AvgMemory, "network": s.AvgNetworkRx}
+}
+
+// Client talks to Railway's GraphQL API on behalf of a single project
+// token. It is safe for concurrent use: the underlying transport.Client
+// owns its own retry/circuit-breaker state.
+type Client struct {
+	Token     string
+	transport *transport.Client
+}
+
+// NewClient builds a Client using the shared retrying transport
+// configured from HTTP_* environment variables.
+func NewClient(token string) *Client {
+	return &Client{Token: token, transport: transport.NewClientFromEnv(endpoint)}
+}
+
+// Fetch returns the average CPU, memory and network-rx readings across all
+// instances of serviceID over the last two poll intervals, plus its
+// current replica count.
+func (c *Client) Fetch(ctx context.Context, serviceID string, interval time.Duration) (Snapshot, error) {
+	now := time.Now()
+	from := now.Add(-2 * interval).Format(time.RFC3339)
+	to := now.Format(time.RFC3339)
+
+	query := `query($id:String!,$from:Time!,$to:Time!){service(id:$id){replicas instances{metrics(from:$from,to:$to,interval:"1m"){cpuPercent memoryPercent networkRxBytes}}}}`
+	variables := map[string]interface{}{"id": serviceID, "from": from, "to": to}
+
+	var resp gqlResponse
+	if err := c.do(ctx, query, variables, &resp); err != nil {
+		return Snapshot{}, err
+	}
+	if len(resp.Errors) > 0 {
+		return Snapshot{}, gqlErrors(resp.Errors)
+	}
+
+	var sumCPU, sumMem, sumNet float64
+	count := 0
+	for _, inst := range resp.Data.Service.Instances {
+		for _, p := range inst.Metrics {
+			sumCPU += p.Cpu
+			sumMem += p.Memory
+			sumNet += p.NetworkRx
+			count++
+		}
+	}
+	snap := Snapshot{Replicas: resp.Data.Service.Replicas}
+	if count > 0 {
+		snap.AvgCPU = sumCPU / float64(count)
+		snap.AvgMemory = sumMem / float64(count)
+		snap.AvgNetworkRx = sumNet / float64(count)
+	}
+	return snap, nil
+}
+
+// CurrentReplicas reads just the replica count, used to make Scale idempotent.
+func (c *Client) CurrentReplicas(ctx context.Context, serviceID string) (int, error) {
+	query := `query($id:String!){service(id:$id){replicas}}`
+	vars := map[string]interface{}{"id": serviceID}
+
+	var resp gqlResponse
+	if err := c.do(ctx, query, vars, &resp); err != nil {
+		return 0, err
+	}
+	if len(resp.Errors) > 0 {
+		return 0, gqlErrors(resp.Errors)
+	}
+	return resp.Data.Service.Replicas, nil
+}
+
+// Scale mutates serviceID's replica count. It first re-reads the current
+// count and short-circuits if it already matches desired, so a retried
+// call doesn't issue a redundant scale event.
+func (c *Client) Scale(ctx context.Context, serviceID string, desired int) error {
+	if current, err := c.CurrentReplicas(ctx, serviceID); err == nil && current == desired {
+		return nil
+	}
+
+	mutation := `mutation($id:String!,$count:Int!){serviceReplicaScale(input:{serviceId:$id,replicas:$count}){id}}`
+	vars := map[string]interface{}{"id": serviceID, "count": desired}
+	var out map[string]interface{}
+	return c.do(ctx, mutation, vars, &out)
+}
+
+func (c *Client) do(ctx context.Context, query string, vars map[string]interface{}, into interface{}) error {
+	body, err := c.transport.Execute(ctx, query, vars, c.Token)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, into)
+}
+
+type gqlErrors []struct{ Message string }
+
+func (e gqlErrors) Error() string {
+	if len(e) == 0 {
+		return "railway: unknown gql error"
+	}
+	return "railway: " + e[0].Message
+}
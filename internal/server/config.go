@@ -0,0 +1,144 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package server holds the autoscaler-server side of the agent/server
+// split: per-service policy config, cooldown state, and the listener
+// that receives MetricSamples and replies with ScaleCommands.
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rmonvfer/autoscaler/internal/policy"
+)
+
+// ServicePolicy is one entry of the server's `services:` config list.
+type ServicePolicy struct {
+	ID       string
+	High     float64
+	Low      float64
+	Min, Max int
+	Cooldown time.Duration
+	Interval time.Duration
+}
+
+func (p ServicePolicy) thresholds() policy.ThresholdParams {
+	return policy.ThresholdParams{High: p.High, Low: p.Low, Min: p.Min, Max: p.Max}
+}
+
+// LoadConfig parses the server's YAML config file. Only the subset of
+// YAML this config actually needs is supported: a top-level `services:`
+// key holding a block sequence of flat `key: value` mappings, e.g.
+//
+//	services:
+//	  - id: srv_123
+//	    high: 75
+//	    low: 30
+//	    min: 1
+//	    max: 5
+//	    cooldown: 2m
+//	    interval: 30s
+func LoadConfig(r io.Reader) ([]ServicePolicy, error) {
+	scanner := bufio.NewScanner(r)
+	var policies []ServicePolicy
+	var cur *ServicePolicy
+	sawServicesKey := false
+
+	flush := func() {
+		if cur != nil {
+			policies = append(policies, *cur)
+			cur = nil
+		}
+	}
+
+	for scanner.Scan() {
+		line := stripComment(scanner.Text())
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		trimmed := strings.TrimLeft(line, " ")
+
+		if !sawServicesKey {
+			if strings.TrimSpace(trimmed) == "services:" {
+				sawServicesKey = true
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "- ") {
+			flush()
+			cur = &ServicePolicy{}
+			trimmed = strings.TrimPrefix(trimmed, "- ")
+		}
+		if cur == nil {
+			return nil, fmt.Errorf("server: config: field outside a services list item: %q", line)
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("server: config: malformed line %q", line)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if err := setField(cur, key, value); err != nil {
+			return nil, fmt.Errorf("server: config: %w", err)
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("server: config: no services defined")
+	}
+	return policies, nil
+}
+
+func setField(p *ServicePolicy, key, value string) error {
+	var err error
+	switch key {
+	case "id":
+		p.ID = value
+	case "high":
+		p.High, err = strconv.ParseFloat(value, 64)
+	case "low":
+		p.Low, err = strconv.ParseFloat(value, 64)
+	case "min":
+		p.Min, err = strconv.Atoi(value)
+	case "max":
+		p.Max, err = strconv.Atoi(value)
+	case "cooldown":
+		p.Cooldown, err = time.ParseDuration(value)
+	case "interval":
+		p.Interval, err = time.ParseDuration(value)
+	default:
+		return fmt.Errorf("unknown field %q", key)
+	}
+	return err
+}
+
+func stripComment(line string) string {
+	if i := strings.Index(line, "#"); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleConfig = `
+# two services with different policies
+services:
+  - id: srv_web
+    high: 80
+    low: 25
+    min: 2
+    max: 10
+    cooldown: 3m
+    interval: 15s
+  - id: srv_worker
+    high: 70
+    low: 20
+    min: 1
+    max: 4
+    cooldown: 1m
+    interval: 30s
+`
+
+func TestLoadConfig_ParsesMultipleServices(t *testing.T) {
+	policies, err := LoadConfig(strings.NewReader(sampleConfig))
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if len(policies) != 2 {
+		t.Fatalf("expected 2 policies, got %d", len(policies))
+	}
+
+	web := policies[0]
+	if web.ID != "srv_web" || web.High != 80 || web.Low != 25 || web.Min != 2 || web.Max != 10 {
+		t.Fatalf("unexpected web policy: %+v", web)
+	}
+	if web.Cooldown != 3*time.Minute || web.Interval != 15*time.Second {
+		t.Fatalf("unexpected web durations: %+v", web)
+	}
+
+	worker := policies[1]
+	if worker.ID != "srv_worker" || worker.Max != 4 {
+		t.Fatalf("unexpected worker policy: %+v", worker)
+	}
+}
+
+func TestLoadConfig_RejectsEmpty(t *testing.T) {
+	if _, err := LoadConfig(strings.NewReader("services:\n")); err == nil {
+		t.Fatalf("expected an error for a services list with no entries")
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package server
+
+import (
+	"context"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/rmonvfer/autoscaler/internal/policy"
+	"github.com/rmonvfer/autoscaler/proto"
+)
+
+// Server holds the policy state (thresholds, cooldowns, last scale time)
+// for every service a configured agent reports on, and answers each
+// incoming MetricSample with a ScaleCommand once cooldown allows it.
+type Server struct {
+	Secret string
+
+	mu        sync.Mutex
+	policies  map[string]ServicePolicy
+	lastScale map[string]time.Time
+}
+
+// NewServer builds a Server for the given per-service policies, keyed by
+// ServicePolicy.ID.
+func NewServer(secret string, policies []ServicePolicy) *Server {
+	byID := make(map[string]ServicePolicy, len(policies))
+	for _, p := range policies {
+		byID[p.ID] = p
+	}
+	return &Server{
+		Secret:    secret,
+		policies:  byID,
+		lastScale: make(map[string]time.Time),
+	}
+}
+
+// Serve accepts agent connections on ln until ctx is done.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		_ = ln.Close()
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+				return err
+			}
+		}
+		go s.handleConn(ctx, conn)
+	}
+}
+
+func (s *Server) handleConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	dec := proto.NewDecoder(conn)
+	enc := proto.NewEncoder(conn)
+	var encMu sync.Mutex
+
+	first, err := dec.Decode()
+	if err != nil || first.Kind != proto.KindHello || first.Hello == nil {
+		log.Printf("server: rejecting connection from %s: missing hello", conn.RemoteAddr())
+		return
+	}
+	if first.Hello.Secret != s.Secret {
+		log.Printf("server: rejecting connection from %s: bad secret", conn.RemoteAddr())
+		return
+	}
+	agentID := first.Hello.AgentID
+	log.Printf("server: agent %s connected from %s, services=%v", agentID, conn.RemoteAddr(), first.Hello.ServiceIDs)
+
+	if err := enc.Encode(proto.Frame{Kind: proto.KindHelloAck, HelloAck: &proto.HelloAck{
+		ServiceIntervals: s.serviceIntervals(first.Hello.ServiceIDs),
+	}}); err != nil {
+		log.Printf("server: agent %s: send hello_ack: %v", agentID, err)
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		frame, err := dec.Decode()
+		if err != nil {
+			log.Printf("server: agent %s disconnected: %v", agentID, err)
+			return
+		}
+
+		switch frame.Kind {
+		case proto.KindMetricSample:
+			s.handleSample(ctx, *frame.MetricSample, &encMu, enc)
+		case proto.KindHeartbeat:
+			// liveness only; nothing to do beyond having read the frame.
+		}
+	}
+}
+
+// serviceIntervals looks up the configured ServicePolicy.Interval for
+// each of serviceIDs, omitting any service with no policy or no
+// configured interval so the agent falls back to its own default.
+func (s *Server) serviceIntervals(serviceIDs []string) map[string]time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	intervals := make(map[string]time.Duration, len(serviceIDs))
+	for _, id := range serviceIDs {
+		if p, ok := s.policies[id]; ok && p.Interval > 0 {
+			intervals[id] = p.Interval
+		}
+	}
+	return intervals
+}
+
+func (s *Server) handleSample(ctx context.Context, sample proto.MetricSample, encMu *sync.Mutex, enc *proto.Encoder) {
+	s.mu.Lock()
+	p, ok := s.policies[sample.ServiceID]
+	if !ok {
+		s.mu.Unlock()
+		log.Printf("server: sample for unconfigured service %s", sample.ServiceID)
+		return
+	}
+	// TODO: ServicePolicy only carries threshold params, so the fleet
+	// path is stuck on the original single-signal policy.Decide even
+	// though the standalone binary can now also run EWMA/PID
+	// (internal/policy.Strategy). Give ServicePolicy a Strategy field and
+	// build it the same way cmd/autoscaler's buildStrategy does, so
+	// multi-service deployments aren't permanently CPU-only/threshold-only.
+	desired := policy.Decide(sample.CPU, sample.Replicas, p.thresholds())
+	last := s.lastScale[sample.ServiceID]
+	cooledDown := time.Since(last) > p.Cooldown
+	if desired != sample.Replicas && cooledDown {
+		s.lastScale[sample.ServiceID] = time.Now()
+	}
+	s.mu.Unlock()
+
+	if desired == sample.Replicas || !cooledDown {
+		return
+	}
+
+	encMu.Lock()
+	defer encMu.Unlock()
+	err := enc.Encode(proto.Frame{
+		Kind:         proto.KindScaleCommand,
+		ScaleCommand: &proto.ScaleCommand{ServiceID: sample.ServiceID, Replicas: desired},
+	})
+	if err != nil {
+		log.Printf("server: failed to send scale command for %s: %v", sample.ServiceID, err)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package transport wraps Railway's GraphQL endpoint with retries,
+// jittered exponential backoff, and a circuit breaker, so a single blip
+// doesn't silently drop a scaling decision until the next poll.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// gqlRequest mirrors the wire payload every GraphQL call sends.
+type gqlRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type gqlErrorExtensions struct {
+	Code string `json:"code"`
+}
+
+type gqlError struct {
+	Message    string             `json:"message"`
+	Extensions gqlErrorExtensions `json:"extensions"`
+}
+
+type gqlEnvelope struct {
+	Errors []gqlError `json:"errors"`
+}
+
+// retryableCodes are GraphQL error extension codes that indicate a
+// transient failure worth retrying. Anything else (validation, auth,
+// not-found, ...) is treated as terminal.
+var retryableCodes = map[string]bool{
+	"INTERNAL_SERVER_ERROR": true,
+	"TIMEOUT":               true,
+	"UNAVAILABLE":           true,
+}
+
+// Client executes GraphQL requests against a fixed endpoint with retries.
+type Client struct {
+	Endpoint   string
+	HTTPClient *http.Client
+
+	MaxRetries        int
+	MinBackoff        time.Duration
+	MaxBackoff        time.Duration
+	PerRequestTimeout time.Duration
+	TotalDeadline     time.Duration
+
+	breaker circuitBreaker
+}
+
+// NewClientFromEnv builds a Client with defaults of 3 retries and a 10s
+// per-request timeout, overridable via
+// HTTP_MAX_RETRIES, HTTP_TIMEOUT, HTTP_BACKOFF_MIN and HTTP_BACKOFF_MAX.
+func NewClientFromEnv(endpoint string) *Client {
+	return &Client{
+		Endpoint:          endpoint,
+		HTTPClient:        http.DefaultClient,
+		MaxRetries:        envInt("HTTP_MAX_RETRIES", 3),
+		MinBackoff:        envDuration("HTTP_BACKOFF_MIN", 200*time.Millisecond),
+		MaxBackoff:        envDuration("HTTP_BACKOFF_MAX", 10*time.Second),
+		PerRequestTimeout: envDuration("HTTP_TIMEOUT", 10*time.Second),
+		TotalDeadline:     envDuration("HTTP_TOTAL_DEADLINE", 30*time.Second),
+		breaker:           circuitBreaker{threshold: 5, cooldown: 30 * time.Second},
+	}
+}
+
+// Execute POSTs query/vars, retrying transient failures, and returns the
+// raw response body for the caller to decode into its own typed struct.
+// A non-nil error means the circuit was open, an attempt failed with a
+// non-retryable error, every attempt was exhausted, or ctx was done; a
+// nil error with a body that still contains terminal GraphQL
+// errors is the caller's responsibility to inspect, same as before.
+func (c *Client) Execute(ctx context.Context, query string, vars map[string]interface{}, token string) ([]byte, error) {
+	if c.breaker.open() {
+		return nil, fmt.Errorf("transport: circuit open, backing off")
+	}
+
+	deadline := time.Now().Add(c.TotalDeadline)
+	payload, err := json.Marshal(gqlRequest{Query: query, Variables: vars})
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		if time.Now().After(deadline) {
+			break
+		}
+
+		body, retryAfter, err := c.attempt(ctx, payload, token)
+		if err == nil {
+			c.breaker.recordSuccess()
+			return body, nil
+		}
+		lastErr = err
+		c.breaker.recordFailure()
+		if !isRetryable(err) || attempt == c.MaxRetries {
+			break
+		}
+
+		backoff := c.backoffFor(attempt)
+		if retryAfter > 0 {
+			backoff = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+	return nil, lastErr
+}
+
+// attemptError carries whether the underlying failure is worth retrying.
+type attemptError struct {
+	err       error
+	retryable bool
+}
+
+func (e *attemptError) Error() string { return e.err.Error() }
+func (e *attemptError) Unwrap() error { return e.err }
+
+func isRetryable(err error) bool {
+	ae, ok := err.(*attemptError)
+	return ok && ae.retryable
+}
+
+// attempt performs a single HTTP round trip and returns the body on
+// success, or a classified error (and an optional Retry-After duration)
+// otherwise.
+func (c *Client) attempt(ctx context.Context, payload []byte, token string) ([]byte, time.Duration, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, c.PerRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, c.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, 0, &attemptError{err: err, retryable: false}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Project-Access-Token", token)
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, 0, &attemptError{err: err, retryable: true}
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, 0, &attemptError{err: err, retryable: true}
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500 {
+		return nil, retryAfterDuration(res.Header.Get("Retry-After")), &attemptError{
+			err:       fmt.Errorf("transport: status %d", res.StatusCode),
+			retryable: true,
+		}
+	}
+	if res.StatusCode >= 400 {
+		return nil, 0, &attemptError{err: fmt.Errorf("transport: status %d", res.StatusCode), retryable: false}
+	}
+
+	var env gqlEnvelope
+	if err := json.Unmarshal(body, &env); err == nil && len(env.Errors) > 0 {
+		for _, e := range env.Errors {
+			if retryableCodes[e.Extensions.Code] {
+				return nil, 0, &attemptError{err: fmt.Errorf("transport: gql error %s: %s", e.Extensions.Code, e.Message), retryable: true}
+			}
+		}
+	}
+
+	return body, 0, nil
+}
+
+func (c *Client) backoffFor(attempt int) time.Duration {
+	backoff := c.MinBackoff * time.Duration(1<<uint(attempt))
+	if backoff > c.MaxBackoff || backoff <= 0 {
+		backoff = c.MaxBackoff
+	}
+	jittered := float64(backoff) * (0.5 + rand.Float64()*0.5)
+	return time.Duration(jittered)
+}
+
+func retryAfterDuration(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return time.Until(at)
+	}
+	return 0
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecute_RetriesOn5xxThenSucceeds(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer srv.Close()
+
+	c := &Client{
+		Endpoint:          srv.URL,
+		HTTPClient:        srv.Client(),
+		MaxRetries:        3,
+		MinBackoff:        time.Millisecond,
+		MaxBackoff:        5 * time.Millisecond,
+		PerRequestTimeout: time.Second,
+		TotalDeadline:     time.Second,
+		breaker:           circuitBreaker{threshold: 10, cooldown: time.Second},
+	}
+
+	body, err := c.Execute(context.Background(), "query{}", nil, "tok")
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if string(body) != `{"data":{}}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestExecute_TerminalGQLErrorDoesNotRetry(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"errors":[{"message":"bad token","extensions":{"code":"UNAUTHENTICATED"}}]}`))
+	}))
+	defer srv.Close()
+
+	c := &Client{
+		Endpoint:          srv.URL,
+		HTTPClient:        srv.Client(),
+		MaxRetries:        3,
+		MinBackoff:        time.Millisecond,
+		MaxBackoff:        5 * time.Millisecond,
+		PerRequestTimeout: time.Second,
+		TotalDeadline:     time.Second,
+		breaker:           circuitBreaker{threshold: 10, cooldown: time.Second},
+	}
+
+	body, err := c.Execute(context.Background(), "query{}", nil, "tok")
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected a terminal gql error to short-circuit after 1 call, got %d", calls)
+	}
+	if len(body) == 0 {
+		t.Fatalf("expected body to be returned for caller-side inspection")
+	}
+}
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := &Client{
+		Endpoint:          srv.URL,
+		HTTPClient:        srv.Client(),
+		MaxRetries:        0,
+		MinBackoff:        time.Millisecond,
+		MaxBackoff:        time.Millisecond,
+		PerRequestTimeout: time.Second,
+		TotalDeadline:     time.Second,
+		breaker:           circuitBreaker{threshold: 2, cooldown: time.Minute},
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Execute(context.Background(), "query{}", nil, "tok"); err == nil {
+			t.Fatalf("expected failure on attempt %d", i)
+		}
+	}
+
+	_, err := c.Execute(context.Background(), "query{}", nil, "tok")
+	if err == nil || !c.breaker.open() {
+		t.Fatalf("expected circuit to be open after threshold failures, err=%v", err)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package proto
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const maxFrameSize = 1 << 20 // 1 MiB, generous for these small JSON frames
+
+// Encoder writes length-prefixed JSON Frames to an underlying stream.
+// Safe for use by a single writer goroutine.
+type Encoder struct {
+	w io.Writer
+}
+
+func NewEncoder(w io.Writer) *Encoder { return &Encoder{w: w} }
+
+func (e *Encoder) Encode(f Frame) error {
+	body, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(body)))
+	if _, err := e.w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err = e.w.Write(body)
+	return err
+}
+
+// Decoder reads length-prefixed JSON Frames from an underlying stream.
+// Safe for use by a single reader goroutine.
+type Decoder struct {
+	r *bufio.Reader
+}
+
+func NewDecoder(r io.Reader) *Decoder { return &Decoder{r: bufio.NewReader(r)} }
+
+func (d *Decoder) Decode() (Frame, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(d.r, header[:]); err != nil {
+		return Frame{}, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxFrameSize {
+		return Frame{}, fmt.Errorf("proto: frame of %d bytes exceeds max %d", size, maxFrameSize)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(d.r, body); err != nil {
+		return Frame{}, err
+	}
+	var f Frame
+	if err := json.Unmarshal(body, &f); err != nil {
+		return Frame{}, err
+	}
+	return f, nil
+}
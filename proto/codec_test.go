@@ -0,0 +1,98 @@
+// Copyright (C) 2025 Ramón Vila Ferreres
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package proto
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecode_RoundTrips(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	dec := NewDecoder(&buf)
+
+	want := Frame{
+		Kind: KindMetricSample,
+		MetricSample: &MetricSample{
+			ServiceID: "srv_123",
+			CPU:       42.5,
+			Replicas:  3,
+			Timestamp: time.Unix(1700000000, 0).UTC(),
+		},
+	}
+	if err := enc.Encode(want); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	got, err := dec.Decode()
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if got.Kind != want.Kind || *got.MetricSample != *want.MetricSample {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+}
+
+func TestEncodeDecode_HelloAckCarriesServiceIntervals(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	dec := NewDecoder(&buf)
+
+	want := Frame{
+		Kind: KindHelloAck,
+		HelloAck: &HelloAck{
+			ServiceIntervals: map[string]time.Duration{"srv_123": 45 * time.Second},
+		},
+	}
+	if err := enc.Encode(want); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	got, err := dec.Decode()
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if got.Kind != want.Kind || got.HelloAck.ServiceIntervals["srv_123"] != 45*time.Second {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+}
+
+func TestEncodeDecode_MultipleFramesInSequence(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	dec := NewDecoder(&buf)
+
+	frames := []Frame{
+		{Kind: KindHeartbeat, Heartbeat: &Heartbeat{AgentID: "a1"}},
+		{Kind: KindScaleCommand, ScaleCommand: &ScaleCommand{ServiceID: "srv_123", Replicas: 4}},
+	}
+	for _, f := range frames {
+		if err := enc.Encode(f); err != nil {
+			t.Fatalf("Encode returned error: %v", err)
+		}
+	}
+	for i, want := range frames {
+		got, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("Decode frame %d returned error: %v", i, err)
+		}
+		if got.Kind != want.Kind {
+			t.Fatalf("frame %d: got kind %s, want %s", i, got.Kind, want.Kind)
+		}
+	}
+}